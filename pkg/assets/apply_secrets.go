@@ -0,0 +1,53 @@
+package assets
+
+import (
+	"context"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// ApplySecrets creates or updates the Secrets rendered by the given
+// manifest files as-is, for callers that already have a complete Secret
+// object to apply rather than a literal data map (see ApplySecretWithData
+// for that case).
+func ApplySecrets(files []string, kubeconfigPath string) error {
+	clientset, err := clientsetFor(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		secret := &corev1.Secret{}
+		if err := yaml.Unmarshal(data, secret); err != nil {
+			return err
+		}
+
+		secrets := clientset.CoreV1().Secrets(secret.Namespace)
+		existing, err := secrets.Get(context.TODO(), secret.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			if _, err := secrets.Create(context.TODO(), secret, metav1.CreateOptions{}); err != nil {
+				return err
+			}
+			klog.Infof("Created secret %s/%s", secret.Namespace, secret.Name)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		secret.ResourceVersion = existing.ResourceVersion
+		if _, err := secrets.Update(context.TODO(), secret, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+		klog.Infof("Updated secret %s/%s", secret.Namespace, secret.Name)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package assets
+
+import (
+	"context"
+	"os"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+func clientsetFor(kubeconfigPath string) (*kubernetes.Clientset, error) {
+	restConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(restConfig)
+}
+
+// DeleteNamespaces removes the namespaces rendered by the given manifest
+// files, ignoring any that are already gone.
+func DeleteNamespaces(files []string, kubeconfigPath string) error {
+	clientset, err := clientsetFor(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		ns := &corev1.Namespace{}
+		if err := yaml.Unmarshal(data, ns); err != nil {
+			return err
+		}
+		if err := clientset.CoreV1().Namespaces().Delete(context.TODO(), ns.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		klog.Infof("Deleted namespace %s", ns.Name)
+	}
+	return nil
+}
+
+// DeleteDeployments removes the Deployments rendered by the given manifest
+// files, ignoring any that are already gone.
+func DeleteDeployments(files []string, kubeconfigPath string) error {
+	clientset, err := clientsetFor(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		d := &appsv1.Deployment{}
+		if err := yaml.Unmarshal(data, d); err != nil {
+			return err
+		}
+		if err := clientset.AppsV1().Deployments(d.Namespace).Delete(context.TODO(), d.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		klog.Infof("Deleted deployment %s/%s", d.Namespace, d.Name)
+	}
+	return nil
+}
+
+// DeleteDaemonSets removes the DaemonSets rendered by the given manifest
+// files, ignoring any that are already gone.
+func DeleteDaemonSets(files []string, kubeconfigPath string) error {
+	clientset, err := clientsetFor(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		ds := &appsv1.DaemonSet{}
+		if err := yaml.Unmarshal(data, ds); err != nil {
+			return err
+		}
+		if err := clientset.AppsV1().DaemonSets(ds.Namespace).Delete(context.TODO(), ds.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		klog.Infof("Deleted daemonset %s/%s", ds.Namespace, ds.Name)
+	}
+	return nil
+}
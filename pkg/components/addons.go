@@ -0,0 +1,345 @@
+package components
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/openshift/microshift/pkg/assets"
+	"github.com/openshift/microshift/pkg/config"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	kyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// manifestsDir is scanned for user-supplied HelmChart manifests, mirroring
+// the k3s convention of dropping bundled manifests under /var/lib/rancher.
+const manifestsDir = "/etc/microshift/manifests.d"
+
+const addonStatusFileName = "addon-charts-status.json"
+
+//go:embed addons/default
+var defaultAddonCharts embed.FS
+
+// HelmChart is the lightweight CRD MicroShift recognizes in manifestsDir,
+// mirroring the pattern k3s uses for its own bundled manifests: a chart
+// reference, a target namespace, inline values, and a disable toggle.
+type HelmChart struct {
+	Metadata HelmChartMetadata `json:"metadata"`
+	Spec     HelmChartSpec     `json:"spec"`
+}
+
+type HelmChartMetadata struct {
+	Name string `json:"name"`
+}
+
+type HelmChartSpec struct {
+	// Chart is a path to a local chart directory or tarball, resolved
+	// relative to the manifest file that declared it; in a manifest from
+	// the embedded default set, which has no directory on disk, a relative
+	// path is passed to Helm's loader unchanged. OCI and chart-repo
+	// references are not supported yet: doing that right needs Helm's
+	// registry/downloader machinery, which MicroShift doesn't vendor.
+	Chart string `json:"chart"`
+
+	TargetNamespace string            `json:"targetNamespace"`
+	ValuesContent   string            `json:"valuesContent"`
+	Disable         bool              `json:"disable"`
+	Set             map[string]string `json:"set"`
+}
+
+// startAddonCharts renders every enabled HelmChart manifest found under
+// manifestsDir and the embedded default set, and applies the result by
+// dispatching each rendered object to the same assets.ApplyXxx helpers the
+// built-in components use, so ownership and kubeconfig plumbing match them.
+// Charts whose rendered output hasn't changed since the last run are
+// skipped.
+func startAddonCharts(cfg *config.MicroshiftConfig, kubeconfigPath string) error {
+	charts, err := loadHelmCharts()
+	if err != nil {
+		return fmt.Errorf("error loading HelmChart manifests: %w", err)
+	}
+
+	previous, err := loadAddonStatus(cfg.DataDir)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	current := make(map[string]string, len(charts))
+	for _, hc := range charts {
+		if hc.Spec.Disable {
+			klog.Infof("HelmChart %q is disabled, skipping", hc.Metadata.Name)
+			continue
+		}
+
+		rendered, err := renderHelmChart(hc, kubeconfigPath)
+		if err != nil {
+			klog.Warningf("Failed to render HelmChart %q: %v", hc.Metadata.Name, err)
+			errs = errors.Join(errs, err)
+			continue
+		}
+
+		digest := sha256Hex(rendered)
+		if previous[hc.Metadata.Name] == digest {
+			klog.V(2).Infof("HelmChart %q unchanged since last release, skipping apply", hc.Metadata.Name)
+			current[hc.Metadata.Name] = digest
+			continue
+		}
+
+		if err := applyRenderedChart(cfg.DataDir, hc.Metadata.Name, rendered, kubeconfigPath); err != nil {
+			klog.Warningf("Failed to apply HelmChart %q: %v", hc.Metadata.Name, err)
+			errs = errors.Join(errs, err)
+			continue
+		}
+		current[hc.Metadata.Name] = digest
+		klog.Infof("Applied HelmChart %q", hc.Metadata.Name)
+	}
+
+	if err := saveAddonStatus(cfg.DataDir, current); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	return errs
+}
+
+// loadHelmCharts reads every *.yaml file in the embedded default set and in
+// manifestsDir and decodes the HelmChart documents they contain.
+func loadHelmCharts() ([]HelmChart, error) {
+	var charts []HelmChart
+
+	defaultFiles, err := defaultAddonCharts.ReadDir("addons/default")
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range defaultFiles {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".yaml" {
+			continue
+		}
+		data, err := defaultAddonCharts.ReadFile(filepath.Join("addons/default", f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		hc, err := decodeHelmChart(data)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding embedded chart %s: %w", f.Name(), err)
+		}
+		charts = append(charts, hc)
+	}
+
+	entries, err := os.ReadDir(manifestsDir)
+	if os.IsNotExist(err) {
+		return charts, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".yaml" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(manifestsDir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		hc, err := decodeHelmChart(data)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding %s: %w", e.Name(), err)
+		}
+		if hc.Spec.Chart != "" && !filepath.IsAbs(hc.Spec.Chart) {
+			hc.Spec.Chart = filepath.Join(manifestsDir, hc.Spec.Chart)
+		}
+		charts = append(charts, hc)
+	}
+	return charts, nil
+}
+
+func decodeHelmChart(data []byte) (HelmChart, error) {
+	var hc HelmChart
+	err := yaml.Unmarshal(data, &hc)
+	return hc, err
+}
+
+// renderHelmChart loads hc.Spec.Chart with the embedded Helm v3 engine and
+// renders it client-side, the same way `helm template` would, so the
+// caller can diff and apply the result without needing a Tiller-style
+// release to already exist.
+func renderHelmChart(hc HelmChart, kubeconfigPath string) ([]byte, error) {
+	chrt, err := loader.Load(hc.Spec.Chart)
+	if err != nil {
+		return nil, fmt.Errorf("error loading chart %q: %w", hc.Spec.Chart, err)
+	}
+
+	values, err := chartutil.ReadValues([]byte(hc.Spec.ValuesContent))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing values for chart %q: %w", hc.Spec.Chart, err)
+	}
+	for k, v := range hc.Spec.Set {
+		values[k] = v
+	}
+
+	actionConfig := new(action.Configuration)
+	actionConfig.Log = func(string, ...interface{}) {}
+	install := action.NewInstall(actionConfig)
+	install.ReleaseName = hc.Metadata.Name
+	install.Namespace = hc.Spec.TargetNamespace
+	install.ClientOnly = true
+	install.DryRun = true
+
+	release, err := install.Run(chrt, values)
+	if err != nil {
+		return nil, fmt.Errorf("error rendering chart %q: %w", hc.Spec.Chart, err)
+	}
+	return []byte(release.Manifest), nil
+}
+
+// renderedChartsDir returns the directory rendered Helm manifests for name
+// are written to before being applied, so the existing assets.ApplyXxx
+// helpers - which all take file paths - can be reused unchanged.
+func renderedChartsDir(dataDir, name string) string {
+	return filepath.Join(dataDir, "resources", "addons", name)
+}
+
+// applyRenderedChart splits manifest into its constituent YAML documents,
+// writes each to renderedChartsDir, and dispatches the files by kind to the
+// same assets.ApplyXxx helpers the built-in components use. Kinds with no
+// in-tree apply helper are logged and skipped rather than silently dropped.
+func applyRenderedChart(dataDir, name string, manifest []byte, kubeconfigPath string) error {
+	dir := renderedChartsDir(dataDir, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("error creating %s: %w", dir, err)
+	}
+
+	docs, err := splitYAMLDocuments(manifest)
+	if err != nil {
+		return fmt.Errorf("error splitting rendered manifest for chart %q: %w", name, err)
+	}
+
+	filesByKind := map[string][]string{}
+	for i, doc := range docs {
+		var obj struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		}
+		if err := yaml.Unmarshal(doc, &obj); err != nil {
+			return fmt.Errorf("error decoding rendered object %d of chart %q: %w", i, name, err)
+		}
+		if obj.Kind == "" {
+			continue
+		}
+
+		path := filepath.Join(dir, fmt.Sprintf("%02d-%s-%s.yaml", i, strings.ToLower(obj.Kind), obj.Metadata.Name))
+		if err := os.WriteFile(path, doc, 0644); err != nil {
+			return fmt.Errorf("error writing %s: %w", path, err)
+		}
+		filesByKind[obj.Kind] = append(filesByKind[obj.Kind], path)
+	}
+
+	var errs error
+	for kind, files := range filesByKind {
+		var err error
+		switch kind {
+		case "Namespace":
+			err = assets.ApplyNamespaces(files, kubeconfigPath)
+		case "ServiceAccount":
+			err = assets.ApplyServiceAccounts(files, kubeconfigPath)
+		case "ClusterRole":
+			err = assets.ApplyClusterRoles(files, kubeconfigPath)
+		case "ClusterRoleBinding":
+			err = assets.ApplyClusterRoleBindings(files, kubeconfigPath)
+		case "Role":
+			err = assets.ApplyRoles(files, kubeconfigPath)
+		case "RoleBinding":
+			err = assets.ApplyRoleBindings(files, kubeconfigPath)
+		case "Secret":
+			err = assets.ApplySecrets(files, kubeconfigPath)
+		case "ConfigMap":
+			err = assets.ApplyConfigMaps(files, nil, nil, kubeconfigPath)
+		case "Service":
+			err = assets.ApplyServices(files, nil, nil, kubeconfigPath)
+		case "Deployment":
+			err = assets.ApplyDeployments(files, nil, nil, kubeconfigPath)
+		case "DaemonSet":
+			err = assets.ApplyDaemonSets(files, nil, nil, kubeconfigPath)
+		default:
+			klog.Warningf("HelmChart %q rendered %d %s object(s), which has no in-tree apply helper yet; skipping", name, len(files), kind)
+			continue
+		}
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("error applying %s objects for chart %q: %w", kind, name, err))
+		}
+	}
+	return errs
+}
+
+// splitYAMLDocuments splits a multi-document YAML manifest (as `helm
+// template`/install.Run produces) into its constituent documents, dropping
+// any that are empty or whitespace-only; a document holding only comments
+// is kept. It uses
+// apimachinery's YAML document reader rather than a naive split on "---",
+// since that literal byte sequence also appears inside PEM banners
+// (e.g. "-----BEGIN CERTIFICATE-----") that charts commonly embed in
+// Secrets.
+func splitYAMLDocuments(manifest []byte) ([][]byte, error) {
+	reader := kyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(manifest)))
+	var docs [][]byte
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func addonStatusFilePath(dataDir string) string {
+	return filepath.Join(dataDir, addonStatusFileName)
+}
+
+func loadAddonStatus(dataDir string) (map[string]string, error) {
+	data, err := os.ReadFile(addonStatusFilePath(dataDir))
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	status := map[string]string{}
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+func saveAddonStatus(dataDir string, status map[string]string) error {
+	data, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(addonStatusFilePath(dataDir), data, 0644)
+}
@@ -0,0 +1,82 @@
+package components
+
+import (
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+func TestSplitYAMLDocuments(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     int
+	}{
+		{
+			name:     "single document",
+			manifest: "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: foo\n",
+			want:     1,
+		},
+		{
+			name: "multiple documents",
+			manifest: "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: foo\n" +
+				"---\n" +
+				"apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: bar\n",
+			want: 2,
+		},
+		{
+			name: "secret with an embedded PEM block is not split mid-document",
+			manifest: "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: foo\n" +
+				"---\n" +
+				"apiVersion: v1\nkind: Secret\nmetadata:\n  name: tls\n" +
+				"data:\n  tls.crt: |\n    -----BEGIN CERTIFICATE-----\n    MIIB...\n    -----END CERTIFICATE-----\n",
+			want: 2,
+		},
+		{
+			name:     "blank document dropped",
+			manifest: "---\napiVersion: v1\nkind: Namespace\nmetadata:\n  name: foo\n---\n",
+			want:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docs, err := splitYAMLDocuments([]byte(tt.manifest))
+			if err != nil {
+				t.Fatalf("splitYAMLDocuments returned error: %v", err)
+			}
+			if len(docs) != tt.want {
+				t.Fatalf("got %d documents, want %d: %q", len(docs), tt.want, docs)
+			}
+		})
+	}
+}
+
+func TestSplitYAMLDocumentsDecodesEachDocument(t *testing.T) {
+	manifest := "apiVersion: v1\nkind: Secret\nmetadata:\n  name: tls\n" +
+		"data:\n  tls.crt: |\n    -----BEGIN CERTIFICATE-----\n    MIIB...\n    -----END CERTIFICATE-----\n" +
+		"---\n" +
+		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n"
+
+	docs, err := splitYAMLDocuments([]byte(manifest))
+	if err != nil {
+		t.Fatalf("splitYAMLDocuments returned error: %v", err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("got %d documents, want 2: %q", len(docs), docs)
+	}
+
+	var secret struct {
+		Kind string            `json:"kind"`
+		Data map[string]string `json:"data"`
+	}
+	if err := yaml.Unmarshal(docs[0], &secret); err != nil {
+		t.Fatalf("error decoding first document: %v", err)
+	}
+	if secret.Kind != "Secret" {
+		t.Fatalf("got kind %q, want Secret", secret.Kind)
+	}
+	if secret.Data["tls.crt"] == "" {
+		t.Fatalf("expected tls.crt to survive the split intact, got %q", secret.Data["tls.crt"])
+	}
+}
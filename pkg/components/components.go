@@ -0,0 +1,69 @@
+package components
+
+import (
+	"errors"
+
+	"github.com/openshift/microshift/pkg/config"
+	"k8s.io/klog/v2"
+)
+
+type component struct {
+	name  string
+	start func(*config.MicroshiftConfig, string) error
+	del   func(string) error
+}
+
+// components is the set of built-in workloads MicroShift manages and that
+// operators may individually opt out of via config.ComponentsConfig.Disable.
+var components = []component{
+	{config.ComponentServiceCA, startServiceCAController, deleteServiceCAController},
+	{config.ComponentOpenShiftRouter, startIngressController, deleteIngressController},
+	{config.ComponentOpenShiftDNS, startDNSController, deleteDNSController},
+	{config.ComponentNodeResolver, startNodeResolverController, deleteNodeResolverController},
+}
+
+// Start brings up every enabled built-in component. For a component that has
+// transitioned from enabled to disabled since the last run, it instead
+// removes the namespaces/Deployments/DaemonSets MicroShift previously
+// applied, so operators can replace it with their own workload. The
+// resulting effective set is persisted to the status file for the next run
+// and for reporting.
+func Start(cfg *config.MicroshiftConfig, kubeconfigPath string) error {
+	previous, err := loadStatus(cfg.DataDir)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	current := make(map[string]bool, len(components))
+	for _, c := range components {
+		enabled := cfg.Components.IsEnabled(c.name)
+		current[c.name] = enabled
+
+		if !enabled {
+			klog.Infof("Component %q is disabled, skipping", c.name)
+			if previous[c.name] {
+				klog.Infof("Component %q was previously enabled, removing its managed resources", c.name)
+				if err := c.del(kubeconfigPath); err != nil {
+					klog.Warningf("Failed to remove resources for disabled component %q: %v", c.name, err)
+					errs = errors.Join(errs, err)
+				}
+			}
+			continue
+		}
+
+		if err := c.start(cfg, kubeconfigPath); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	if err := saveStatus(cfg.DataDir, current); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	if err := startAddonCharts(cfg, kubeconfigPath); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	return errs
+}
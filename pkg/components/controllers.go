@@ -1,6 +1,7 @@
 package components
 
 import (
+	"errors"
 	"os"
 
 	"github.com/openshift/microshift/pkg/assets"
@@ -60,47 +61,50 @@ func startServiceCAController(cfg *config.MicroshiftConfig, kubeconfigPath strin
 	secretData["tls.crt"] = tlscrt
 	secretData["tls.key"] = tlskey
 
-	if err := assets.ApplyNamespaces(ns, kubeconfigPath); err != nil {
+	var errs error
+	if err := applyWithRetry(func() error { return assets.ApplyNamespaces(ns, kubeconfigPath) }); err != nil {
 		klog.Warningf("Failed to apply ns %v: %v", ns, err)
-		return err
+		errs = errors.Join(errs, err)
 	}
-	if err := assets.ApplyClusterRoleBindings(clusterRoleBinding, kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error { return assets.ApplyClusterRoleBindings(clusterRoleBinding, kubeconfigPath) }); err != nil {
 		klog.Warningf("Failed to apply clusterRolebinding %v: %v", clusterRoleBinding, err)
-		return err
+		errs = errors.Join(errs, err)
 	}
-	if err := assets.ApplyClusterRoles(clusterRole, kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error { return assets.ApplyClusterRoles(clusterRole, kubeconfigPath) }); err != nil {
 		klog.Warningf("Failed to apply clusterRole %v: %v", clusterRole, err)
-		return err
+		errs = errors.Join(errs, err)
 	}
-	if err := assets.ApplyRoleBindings(roleBinding, kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error { return assets.ApplyRoleBindings(roleBinding, kubeconfigPath) }); err != nil {
 		klog.Warningf("Failed to apply rolebinding %v: %v", roleBinding, err)
-		return err
+		errs = errors.Join(errs, err)
 	}
-	if err := assets.ApplyRoles(role, kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error { return assets.ApplyRoles(role, kubeconfigPath) }); err != nil {
 		klog.Warningf("Failed to apply role %v: %v", role, err)
-		return err
+		errs = errors.Join(errs, err)
 	}
-	if err := assets.ApplyServiceAccounts(sa, kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error { return assets.ApplyServiceAccounts(sa, kubeconfigPath) }); err != nil {
 		klog.Warningf("Failed to apply sa %v: %v", sa, err)
-		return err
+		errs = errors.Join(errs, err)
 	}
-	if err := assets.ApplySecretWithData(secret, secretData, kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error { return assets.ApplySecretWithData(secret, secretData, kubeconfigPath) }); err != nil {
 		klog.Warningf("Failed to apply secret %v: %v", secret, err)
-		return err
+		errs = errors.Join(errs, err)
 	}
-	if err := assets.ApplyConfigMapWithData(cm, cmData, kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error { return assets.ApplyConfigMapWithData(cm, cmData, kubeconfigPath) }); err != nil {
 		klog.Warningf("Failed to apply sa %v: %v", cm, err)
-		return err
+		errs = errors.Join(errs, err)
 	}
 	extraParams := assets.RenderParams{
 		"CAConfigMap": cmName,
 		"TLSSecret":   secretName,
 	}
-	if err := assets.ApplyDeployments(apps, renderTemplate, renderParamsFromConfig(cfg, extraParams), kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error {
+		return assets.ApplyDeployments(apps, renderTemplate, renderParamsFromConfig(cfg, extraParams), kubeconfigPath)
+	}); err != nil {
 		klog.Warningf("Failed to apply apps %v: %v", apps, err)
-		return err
+		errs = errors.Join(errs, err)
 	}
-	return nil
+	return errs
 }
 
 func startIngressController(cfg *config.MicroshiftConfig, kubeconfigPath string) error {
@@ -132,39 +136,42 @@ func startIngressController(cfg *config.MicroshiftConfig, kubeconfigPath string)
 			"assets/components/openshift-router/service-cloud.yaml",
 		}
 	)
-	if err := assets.ApplyNamespaces(ns, kubeconfigPath); err != nil {
+	var errs error
+	if err := applyWithRetry(func() error { return assets.ApplyNamespaces(ns, kubeconfigPath) }); err != nil {
 		klog.Warningf("Failed to apply namespaces %v: %v", ns, err)
-		return err
+		errs = errors.Join(errs, err)
 	}
-	if err := assets.ApplyClusterRoles(clusterRole, kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error { return assets.ApplyClusterRoles(clusterRole, kubeconfigPath) }); err != nil {
 		klog.Warningf("Failed to apply clusterRole %v: %v", clusterRole, err)
-		return err
+		errs = errors.Join(errs, err)
 	}
-	if err := assets.ApplyClusterRoleBindings(clusterRoleBinding, kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error { return assets.ApplyClusterRoleBindings(clusterRoleBinding, kubeconfigPath) }); err != nil {
 		klog.Warningf("Failed to apply clusterRolebinding %v: %v", clusterRoleBinding, err)
-		return err
+		errs = errors.Join(errs, err)
 	}
-	if err := assets.ApplyServiceAccounts(sa, kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error { return assets.ApplyServiceAccounts(sa, kubeconfigPath) }); err != nil {
 		klog.Warningf("Failed to apply serviceAccount %v %v", sa, err)
-		return err
+		errs = errors.Join(errs, err)
 	}
-	if err := assets.ApplyConfigMaps(cm, nil, nil, kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error { return assets.ApplyConfigMaps(cm, nil, nil, kubeconfigPath) }); err != nil {
 		klog.Warningf("Failed to apply configMap %v, %v", cm, err)
-		return err
+		errs = errors.Join(errs, err)
 	}
-	if err := assets.ApplyServices(svc, nil, nil, kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error { return assets.ApplyServices(svc, nil, nil, kubeconfigPath) }); err != nil {
 		klog.Warningf("Failed to apply service %v %v", svc, err)
-		return err
+		errs = errors.Join(errs, err)
 	}
-	if err := assets.ApplyServices(extSvc, nil, nil, kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error { return assets.ApplyServices(extSvc, nil, nil, kubeconfigPath) }); err != nil {
 		klog.Warningf("Failed to apply external ingress svc %v: %v", extSvc, err)
-		return err
+		errs = errors.Join(errs, err)
 	}
-	if err := assets.ApplyDeployments(apps, renderTemplate, renderParamsFromConfig(cfg, nil), kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error {
+		return assets.ApplyDeployments(apps, renderTemplate, renderParamsFromConfig(cfg, nil), kubeconfigPath)
+	}); err != nil {
 		klog.Warningf("Failed to apply apps %v: %v", apps, err)
-		return err
+		errs = errors.Join(errs, err)
 	}
-	return nil
+	return errs
 }
 
 func startDNSController(cfg *config.MicroshiftConfig, kubeconfigPath string) error {
@@ -177,14 +184,12 @@ func startDNSController(cfg *config.MicroshiftConfig, kubeconfigPath string) err
 		}
 		apps = []string{
 			"assets/components/openshift-dns/dns/daemonset.yaml",
-			"assets/components/openshift-dns/node-resolver/daemonset.yaml",
 		}
 		ns = []string{
 			"assets/components/openshift-dns/dns/namespace.yaml",
 		}
 		sa = []string{
 			"assets/components/openshift-dns/dns/service-account.yaml",
-			"assets/components/openshift-dns/node-resolver/service-account.yaml",
 		}
 		cm = []string{
 			"assets/components/openshift-dns/dns/configmap.yaml",
@@ -193,36 +198,124 @@ func startDNSController(cfg *config.MicroshiftConfig, kubeconfigPath string) err
 			"assets/components/openshift-dns/dns/service.yaml",
 		}
 	)
-	if err := assets.ApplyNamespaces(ns, kubeconfigPath); err != nil {
-		klog.Warningf("Failed to apply", "namespace", ns, "err", err)
-		return err
+	var errs error
+	if err := applyWithRetry(func() error { return assets.ApplyNamespaces(ns, kubeconfigPath) }); err != nil {
+		klog.Warningf("Failed to apply namespace %v: %v", ns, err)
+		errs = errors.Join(errs, err)
 	}
 	extraParams := assets.RenderParams{
 		"ClusterIP": cfg.Cluster.DNS,
 	}
-	if err := assets.ApplyServices(svc, renderTemplate, renderParamsFromConfig(cfg, extraParams), kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error {
+		return assets.ApplyServices(svc, renderTemplate, renderParamsFromConfig(cfg, extraParams), kubeconfigPath)
+	}); err != nil {
+		// service already created by coreDNS, not an error worth aggregating.
 		klog.Warningf("Failed to apply service %v %v", svc, err)
-		// service already created by coreDNS, not re-create it.
-		return nil
 	}
-	if err := assets.ApplyClusterRoles(clusterRole, kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error { return assets.ApplyClusterRoles(clusterRole, kubeconfigPath) }); err != nil {
 		klog.Warningf("Failed to apply clusterRole %v %v", clusterRole, err)
-		return err
+		errs = errors.Join(errs, err)
 	}
-	if err := assets.ApplyClusterRoleBindings(clusterRoleBinding, kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error { return assets.ApplyClusterRoleBindings(clusterRoleBinding, kubeconfigPath) }); err != nil {
 		klog.Warningf("Failed to apply clusterRoleBinding %v %v", clusterRoleBinding, err)
-		return err
+		errs = errors.Join(errs, err)
 	}
-	if err := assets.ApplyServiceAccounts(sa, kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error { return assets.ApplyServiceAccounts(sa, kubeconfigPath) }); err != nil {
 		klog.Warningf("Failed to apply serviceAccount %v %v", sa, err)
-		return err
+		errs = errors.Join(errs, err)
 	}
-	if err := assets.ApplyConfigMaps(cm, nil, nil, kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error { return assets.ApplyConfigMaps(cm, nil, nil, kubeconfigPath) }); err != nil {
 		klog.Warningf("Failed to apply configMap %v %v", cm, err)
-		return err
+		errs = errors.Join(errs, err)
+	}
+	if err := applyWithRetry(func() error {
+		return assets.ApplyDaemonSets(apps, renderTemplate, renderParamsFromConfig(cfg, extraParams), kubeconfigPath)
+	}); err != nil {
+		klog.Warningf("Failed to apply apps %v %v", apps, err)
+		errs = errors.Join(errs, err)
+	}
+	return errs
+}
+
+func startNodeResolverController(cfg *config.MicroshiftConfig, kubeconfigPath string) error {
+	var (
+		apps = []string{
+			"assets/components/openshift-dns/node-resolver/daemonset.yaml",
+		}
+		sa = []string{
+			"assets/components/openshift-dns/node-resolver/service-account.yaml",
+		}
+	)
+	var errs error
+	if err := applyWithRetry(func() error { return assets.ApplyServiceAccounts(sa, kubeconfigPath) }); err != nil {
+		klog.Warningf("Failed to apply serviceAccount %v %v", sa, err)
+		errs = errors.Join(errs, err)
 	}
-	if err := assets.ApplyDaemonSets(apps, renderTemplate, renderParamsFromConfig(cfg, extraParams), kubeconfigPath); err != nil {
+	if err := applyWithRetry(func() error {
+		return assets.ApplyDaemonSets(apps, renderTemplate, renderParamsFromConfig(cfg, nil), kubeconfigPath)
+	}); err != nil {
 		klog.Warningf("Failed to apply apps %v %v", apps, err)
+		errs = errors.Join(errs, err)
+	}
+	return errs
+}
+
+// deleteServiceCAController removes the namespace and Deployment previously
+// applied by startServiceCAController, so an operator can swap in their own
+// service-serving-certificate signer.
+func deleteServiceCAController(kubeconfigPath string) error {
+	apps := []string{"assets/components/service-ca/deployment.yaml"}
+	ns := []string{"assets/components/service-ca/ns.yaml"}
+	if err := assets.DeleteDeployments(apps, kubeconfigPath); err != nil {
+		klog.Warningf("Failed to delete apps %v: %v", apps, err)
+		return err
+	}
+	if err := assets.DeleteNamespaces(ns, kubeconfigPath); err != nil {
+		klog.Warningf("Failed to delete ns %v: %v", ns, err)
+		return err
+	}
+	return nil
+}
+
+// deleteIngressController removes the namespace and Deployment previously
+// applied by startIngressController, so an operator can swap in their own
+// IngressController.
+func deleteIngressController(kubeconfigPath string) error {
+	apps := []string{"assets/components/openshift-router/deployment.yaml"}
+	ns := []string{"assets/components/openshift-router/namespace.yaml"}
+	if err := assets.DeleteDeployments(apps, kubeconfigPath); err != nil {
+		klog.Warningf("Failed to delete apps %v: %v", apps, err)
+		return err
+	}
+	if err := assets.DeleteNamespaces(ns, kubeconfigPath); err != nil {
+		klog.Warningf("Failed to delete ns %v: %v", ns, err)
+		return err
+	}
+	return nil
+}
+
+// deleteDNSController removes the namespace and DaemonSet previously
+// applied by startDNSController, so an operator can run an external DNS.
+func deleteDNSController(kubeconfigPath string) error {
+	apps := []string{"assets/components/openshift-dns/dns/daemonset.yaml"}
+	ns := []string{"assets/components/openshift-dns/dns/namespace.yaml"}
+	if err := assets.DeleteDaemonSets(apps, kubeconfigPath); err != nil {
+		klog.Warningf("Failed to delete apps %v: %v", apps, err)
+		return err
+	}
+	if err := assets.DeleteNamespaces(ns, kubeconfigPath); err != nil {
+		klog.Warningf("Failed to delete ns %v: %v", ns, err)
+		return err
+	}
+	return nil
+}
+
+// deleteNodeResolverController removes the node-resolver DaemonSet
+// previously applied by startNodeResolverController.
+func deleteNodeResolverController(kubeconfigPath string) error {
+	apps := []string{"assets/components/openshift-dns/node-resolver/daemonset.yaml"}
+	if err := assets.DeleteDaemonSets(apps, kubeconfigPath); err != nil {
+		klog.Warningf("Failed to delete apps %v: %v", apps, err)
 		return err
 	}
 	return nil
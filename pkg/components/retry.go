@@ -0,0 +1,35 @@
+package components
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// retryBackoff bounds the number of attempts and the delay between them when
+// applying an individual asset, absorbing transient apiserver unavailability
+// during boot (common on low-power edge nodes) without giving up on a
+// component for the lifetime of the process.
+var retryBackoff = wait.Backoff{
+	Duration: 1 * time.Second,
+	Factor:   2.0,
+	Steps:    5,
+	Cap:      30 * time.Second,
+}
+
+// applyWithRetry retries apply with exponential backoff until it succeeds or
+// the backoff is exhausted, returning the last error seen.
+func applyWithRetry(apply func() error) error {
+	var lastErr error
+	err := wait.ExponentialBackoff(retryBackoff, func() (bool, error) {
+		if err := apply(); err != nil {
+			lastErr = err
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return lastErr
+	}
+	return nil
+}
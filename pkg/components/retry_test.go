@@ -0,0 +1,50 @@
+package components
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestApplyWithRetrySucceedsAfterTransientFailures(t *testing.T) {
+	origBackoff := retryBackoff
+	retryBackoff.Duration = time.Millisecond
+	retryBackoff.Cap = time.Millisecond
+	defer func() { retryBackoff = origBackoff }()
+
+	attempts := 0
+	err := applyWithRetry(func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("applyWithRetry returned error after eventual success: %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestApplyWithRetryReturnsLastErrorWhenExhausted(t *testing.T) {
+	origBackoff := retryBackoff
+	retryBackoff.Duration = time.Millisecond
+	retryBackoff.Cap = time.Millisecond
+	retryBackoff.Steps = 3
+	defer func() { retryBackoff = origBackoff }()
+
+	attempts := 0
+	wantErr := errors.New("persistent failure")
+	err := applyWithRetry(func() error {
+		attempts++
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected %v, got %v", wantErr, err)
+	}
+	if attempts != retryBackoff.Steps {
+		t.Fatalf("expected %d attempts, got %d", retryBackoff.Steps, attempts)
+	}
+}
@@ -0,0 +1,46 @@
+package components
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+const statusFileName = "components-status.json"
+
+// componentStatus is the on-disk record of which built-in components were
+// enabled the last time Start ran, used to detect enabled->disabled
+// transitions that require cleanup.
+type componentStatus struct {
+	Enabled map[string]bool `json:"enabled"`
+}
+
+func statusFilePath(dataDir string) string {
+	return filepath.Join(dataDir, statusFileName)
+}
+
+func loadStatus(dataDir string) (map[string]bool, error) {
+	data, err := os.ReadFile(statusFilePath(dataDir))
+	if os.IsNotExist(err) {
+		return map[string]bool{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var status componentStatus
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, err
+	}
+	if status.Enabled == nil {
+		status.Enabled = map[string]bool{}
+	}
+	return status.Enabled, nil
+}
+
+func saveStatus(dataDir string, enabled map[string]bool) error {
+	data, err := json.MarshalIndent(componentStatus{Enabled: enabled}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(statusFilePath(dataDir), data, 0644)
+}
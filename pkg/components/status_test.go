@@ -0,0 +1,53 @@
+package components
+
+import "testing"
+
+func TestLoadStatusMissingFileReturnsEmpty(t *testing.T) {
+	enabled, err := loadStatus(t.TempDir())
+	if err != nil {
+		t.Fatalf("loadStatus returned error for missing file: %v", err)
+	}
+	if len(enabled) != 0 {
+		t.Fatalf("expected empty status, got %v", enabled)
+	}
+}
+
+func TestSaveStatusThenLoadStatusRoundTrips(t *testing.T) {
+	dataDir := t.TempDir()
+	want := map[string]bool{"service-ca": true, "openshift-router": false}
+
+	if err := saveStatus(dataDir, want); err != nil {
+		t.Fatalf("saveStatus returned error: %v", err)
+	}
+
+	got, err := loadStatus(dataDir)
+	if err != nil {
+		t.Fatalf("loadStatus returned error: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for name, enabled := range want {
+		if got[name] != enabled {
+			t.Fatalf("status[%q] = %v, want %v", name, got[name], enabled)
+		}
+	}
+}
+
+func TestSaveStatusDetectsEnabledToDisabledTransition(t *testing.T) {
+	dataDir := t.TempDir()
+
+	if err := saveStatus(dataDir, map[string]bool{"node-resolver": true}); err != nil {
+		t.Fatalf("saveStatus returned error: %v", err)
+	}
+
+	previous, err := loadStatus(dataDir)
+	if err != nil {
+		t.Fatalf("loadStatus returned error: %v", err)
+	}
+
+	current := map[string]bool{"node-resolver": false}
+	if !previous["node-resolver"] || current["node-resolver"] {
+		t.Fatalf("expected an observable enabled->disabled transition, previous=%v current=%v", previous, current)
+	}
+}
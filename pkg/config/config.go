@@ -0,0 +1,87 @@
+package config
+
+import "path/filepath"
+
+// DataDir is the process-wide MicroShift data directory, set once at
+// startup. Packages that run before a *Config is threaded through to them
+// (e.g. telemetry's cert loading) read it directly.
+var DataDir string
+
+// NodeConfig holds settings specific to the node MicroShift is running on.
+type NodeConfig struct {
+	HostnameOverride string
+}
+
+// TelemetryConfig configures the remote_write pipeline that ships an
+// allowlisted subset of cluster metrics to an upstream collector.
+type TelemetryConfig struct {
+	// Endpoint is the remote_write URL metrics are POSTed to.
+	Endpoint string
+
+	// MetricsAllowlist is the set of metric family names the pipeline is
+	// permitted to ship. When empty, the pipeline falls back to its
+	// embedded default list.
+	MetricsAllowlist []string
+
+	// BearerTokenFile, if set, is read on each send and used as the
+	// Authorization header.
+	BearerTokenFile string
+
+	// TLSCertFile/TLSKeyFile/TLSCAFile configure mTLS against Endpoint.
+	// They are optional and may be used instead of, or alongside,
+	// BearerTokenFile.
+	TLSCertFile string
+	TLSKeyFile  string
+	TLSCAFile   string
+}
+
+// KubeConfigRootAdminPath returns the directory holding the cluster-admin
+// kubeconfig MicroShift generates for itself.
+func (c *MicroshiftConfig) KubeConfigRootAdminPath() string {
+	return filepath.Join(c.DataDir, "resources", "kubeadmin")
+}
+
+// MicroshiftConfig is the root of the on-disk MicroShift configuration.
+type MicroshiftConfig struct {
+	DataDir string
+
+	Cluster    ClusterConfig
+	Components ComponentsConfig
+	Node       NodeConfig
+	Telemetry  TelemetryConfig
+}
+
+// ClusterConfig holds cluster-wide network settings.
+type ClusterConfig struct {
+	DNS string
+}
+
+// Component names for the built-in, always-available MicroShift workloads.
+// These mirror the names k3s uses for its own --disable flag so operators
+// moving between the two feel at home.
+const (
+	ComponentServiceCA       = "service-ca"
+	ComponentOpenShiftRouter = "openshift-router"
+	ComponentOpenShiftDNS    = "openshift-dns"
+	ComponentNodeResolver    = "node-resolver"
+)
+
+// ComponentsConfig lets operators opt individual built-in components out of
+// MicroShift's boot sequence, e.g. to replace the bundled openshift-router
+// with their own IngressController or run an external DNS.
+type ComponentsConfig struct {
+	// Disable is the set of component names to skip at startup. Names match
+	// the ComponentXxx constants in this package.
+	Disable []string
+}
+
+// IsEnabled reports whether the named component has not been opted out of
+// via Disable.
+func (c ComponentsConfig) IsEnabled(name string) bool {
+	for _, d := range c.Disable {
+		if d == name {
+			return false
+		}
+	}
+	return true
+}
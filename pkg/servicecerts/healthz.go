@@ -0,0 +1,34 @@
+package servicecerts
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type healthzResponse struct {
+	NextExpiry   string `json:"nextExpiry,omitempty"`
+	LastRotation string `json:"lastRotation,omitempty"`
+	LastError    string `json:"lastError,omitempty"`
+}
+
+// HealthzHandler serves the current signer status at /healthz/service-ca
+// so operators running disconnected edge sites can alert before the
+// signer expires.
+func (r *Rotator) HealthzHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		status := r.Status()
+		resp := healthzResponse{LastError: status.LastError}
+		if !status.NextExpiry.IsZero() {
+			resp.NextExpiry = status.NextExpiry.Format("2006-01-02T15:04:05Z07:00")
+		}
+		if !status.LastRotation.IsZero() {
+			resp.LastRotation = status.LastRotation.Format("2006-01-02T15:04:05Z07:00")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if resp.LastError != "" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
@@ -0,0 +1,32 @@
+package servicecerts
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// These gauges are registered against the default Prometheus registry, so
+// they're picked up by the telemetry pipeline's process-metrics gather
+// alongside the kubelet scrape, letting disconnected edge sites alert on a
+// signer that's drifting toward expiry before it breaks every
+// service-serving cert in the cluster.
+var (
+	nextExpirySeconds = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "microshift_service_ca_signer_expiry_seconds",
+		Help: "Unix timestamp of the service-ca signing certificate's expiry.",
+	})
+	lastRotationSeconds = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "microshift_service_ca_signer_last_rotation_seconds",
+		Help: "Unix timestamp of the last successful service-ca signer rotation.",
+	})
+)
+
+func observeNextExpiry(t time.Time) {
+	nextExpirySeconds.Set(float64(t.Unix()))
+}
+
+func observeRotation(t time.Time) {
+	lastRotationSeconds.Set(float64(t.Unix()))
+}
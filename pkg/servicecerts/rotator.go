@@ -0,0 +1,313 @@
+/*
+Copyright © 2025 MicroShift Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package servicecerts keeps the service-ca signer's certificate fresh for
+// the lifetime of a long-running, potentially disconnected, edge node.
+package servicecerts
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+
+	"github.com/openshift/microshift/pkg/config"
+	"github.com/openshift/microshift/pkg/util/cryptomaterial"
+)
+
+const (
+	signingSecretName    = "signing-key"
+	signingConfigMapName = "signing-cabundle"
+	serviceCANamespace   = "openshift-service-ca"
+	serviceCADeployment  = "service-ca"
+	rotatedAtAnnotation  = "microshift.io/service-ca-rotated-at"
+)
+
+// defaultCheckInterval is how often the Rotator looks at the signing
+// cert's expiry; defaultRenewBefore is how far ahead of expiry it
+// regenerates the signer, giving disconnected edge sites ample warning
+// before a missed rotation could break every service-serving cert in the
+// cluster.
+const (
+	defaultCheckInterval = 10 * time.Minute
+	defaultRenewBefore   = 30 * 24 * time.Hour
+)
+
+// Status is a point-in-time snapshot of the signer's health, served by the
+// /healthz/service-ca endpoint and mirrored into Prometheus gauges.
+type Status struct {
+	NextExpiry   time.Time
+	LastRotation time.Time
+	LastError    string
+}
+
+// Rotator watches the on-disk service-ca signing certificate and
+// regenerates it, along with the signing-key Secret and signing-cabundle
+// ConfigMap it feeds, before it expires.
+type Rotator struct {
+	cfg            *config.MicroshiftConfig
+	kubeconfigPath string
+	checkInterval  time.Duration
+	renewBefore    time.Duration
+
+	mu     sync.RWMutex
+	status Status
+}
+
+// NewRotator builds a Rotator for the service-ca signer backing cfg. Call
+// Run to start watching.
+func NewRotator(cfg *config.MicroshiftConfig, kubeconfigPath string) *Rotator {
+	return &Rotator{
+		cfg:            cfg,
+		kubeconfigPath: kubeconfigPath,
+		checkInterval:  defaultCheckInterval,
+		renewBefore:    defaultRenewBefore,
+	}
+}
+
+// Status returns the most recent expiry/rotation snapshot.
+func (r *Rotator) Status() Status {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.status
+}
+
+// Run watches the signing cert's expiry until ctx is canceled, rotating it
+// whenever it's within renewBefore of expiring.
+func (r *Rotator) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.checkInterval)
+	defer ticker.Stop()
+
+	r.reconcile(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.reconcile(ctx)
+		}
+	}
+}
+
+func (r *Rotator) reconcile(ctx context.Context) {
+	tlsCrtPath := r.cfg.DataDir + "/resources/service-ca/secrets/service-ca/tls.crt"
+
+	expiry, err := readCertExpiry(tlsCrtPath)
+	if err != nil {
+		klog.Warningf("service-ca rotator: failed to read signing cert expiry: %v", err)
+		r.recordError(err)
+		return
+	}
+
+	r.mu.Lock()
+	r.status.NextExpiry = expiry
+	r.status.LastError = ""
+	r.mu.Unlock()
+	observeNextExpiry(expiry)
+
+	if time.Until(expiry) > r.renewBefore {
+		return
+	}
+
+	klog.Infof("service-ca rotator: signing cert expires %s, within renewal window; rotating", expiry)
+	if err := r.rotate(ctx); err != nil {
+		klog.Warningf("service-ca rotator: rotation failed: %v", err)
+		r.recordError(err)
+		return
+	}
+
+	now := time.Now()
+	r.mu.Lock()
+	r.status.LastRotation = now
+	r.mu.Unlock()
+	observeRotation(now)
+	klog.Infof("service-ca rotator: rotated signing cert at %s", now)
+}
+
+// rotate regenerates the signing key/cert and folds the new public cert
+// into the same ultimate trust bundle used at initial install - in place
+// of the old signer's entry - so everything that already trusts that
+// bundle keeps trusting service-serving certs after the swap. It then
+// pushes the result to the signing-key Secret and signing-cabundle
+// ConfigMap, and annotates the service-ca Deployment so kubelet rolls its
+// pod to pick up the change.
+func (r *Rotator) rotate(ctx context.Context) error {
+	certsDir := cryptomaterial.CertsDirectory(r.cfg.DataDir)
+	caPath := cryptomaterial.UltimateTrustBundlePath(certsDir)
+
+	tlsCrtPath := r.cfg.DataDir + "/resources/service-ca/secrets/service-ca/tls.crt"
+	tlsKeyPath := r.cfg.DataDir + "/resources/service-ca/secrets/service-ca/tls.key"
+
+	oldCrtPEM, err := os.ReadFile(tlsCrtPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error reading previous signing cert: %w", err)
+	}
+
+	crtPEM, keyPEM, err := cryptomaterial.NewSelfSignedCACertKey("openshift-service-serving-signer", cryptomaterial.ValidityOneYear)
+	if err != nil {
+		return fmt.Errorf("error generating new signing cert: %w", err)
+	}
+	if err := os.WriteFile(tlsCrtPath, crtPEM, 0644); err != nil {
+		return fmt.Errorf("error writing new signing cert: %w", err)
+	}
+	if err := os.WriteFile(tlsKeyPath, keyPEM, 0600); err != nil {
+		return fmt.Errorf("error writing new signing key: %w", err)
+	}
+
+	bundle, err := os.ReadFile(caPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error reading ultimate trust bundle: %w", err)
+	}
+	bundle = replaceCertInBundle(bundle, oldCrtPEM, crtPEM)
+	if err := os.WriteFile(caPath, bundle, 0644); err != nil {
+		return fmt.Errorf("error writing ultimate trust bundle: %w", err)
+	}
+
+	clientset, err := clientsetFor(r.kubeconfigPath)
+	if err != nil {
+		return err
+	}
+
+	if err := updateSigningSecret(ctx, clientset, crtPEM, keyPEM); err != nil {
+		return fmt.Errorf("error updating %s secret: %w", signingSecretName, err)
+	}
+	if err := updateSigningConfigMap(ctx, clientset, bundle); err != nil {
+		return fmt.Errorf("error updating %s configmap: %w", signingConfigMapName, err)
+	}
+	if err := annotateDeploymentForRollout(ctx, clientset); err != nil {
+		return fmt.Errorf("error annotating %s deployment: %w", serviceCADeployment, err)
+	}
+	return nil
+}
+
+// replaceCertInBundle returns bundle with any PEM certificate matching
+// oldCert removed and newCert appended, so the ultimate trust bundle keeps
+// validating service-serving certs once the signer has rotated. oldCert
+// may be empty, as when no signing cert was on disk before the rotation,
+// in which case newCert is simply added.
+func replaceCertInBundle(bundle, oldCert, newCert []byte) []byte {
+	var oldDER []byte
+	if block, _ := pem.Decode(oldCert); block != nil {
+		oldDER = block.Bytes
+	}
+
+	var out bytes.Buffer
+	rest := bundle
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if oldDER != nil && bytes.Equal(block.Bytes, oldDER) {
+			continue
+		}
+		out.Write(pem.EncodeToMemory(block))
+	}
+	out.Write(newCert)
+	return out.Bytes()
+}
+
+func readCertExpiry(tlsCrtPath string) (time.Time, error) {
+	data, err := os.ReadFile(tlsCrtPath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return time.Time{}, fmt.Errorf("no PEM block found in %s", tlsCrtPath)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cert.NotAfter, nil
+}
+
+func clientsetFor(kubeconfigPath string) (*kubernetes.Clientset, error) {
+	restConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(restConfig)
+}
+
+func updateSigningSecret(ctx context.Context, clientset *kubernetes.Clientset, crtPEM, keyPEM []byte) error {
+	secrets := clientset.CoreV1().Secrets(serviceCANamespace)
+	secret, err := secrets.Get(ctx, signingSecretName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		secret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: signingSecretName, Namespace: serviceCANamespace}}
+	} else if err != nil {
+		return err
+	}
+	secret.Data = map[string][]byte{"tls.crt": crtPEM, "tls.key": keyPEM}
+
+	if secret.ResourceVersion == "" {
+		_, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+	} else {
+		_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+	}
+	return err
+}
+
+func updateSigningConfigMap(ctx context.Context, clientset *kubernetes.Clientset, cabundle []byte) error {
+	configMaps := clientset.CoreV1().ConfigMaps(serviceCANamespace)
+	cm, err := configMaps.Get(ctx, signingConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: signingConfigMapName, Namespace: serviceCANamespace}}
+	} else if err != nil {
+		return err
+	}
+	cm.Data = map[string]string{"ca-bundle.crt": string(cabundle)}
+
+	if cm.ResourceVersion == "" {
+		_, err = configMaps.Create(ctx, cm, metav1.CreateOptions{})
+	} else {
+		_, err = configMaps.Update(ctx, cm, metav1.UpdateOptions{})
+	}
+	return err
+}
+
+func annotateDeploymentForRollout(ctx context.Context, clientset *kubernetes.Clientset) error {
+	deployments := clientset.AppsV1().Deployments(serviceCANamespace)
+	deployment, err := deployments.Get(ctx, serviceCADeployment, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if deployment.Spec.Template.Annotations == nil {
+		deployment.Spec.Template.Annotations = map[string]string{}
+	}
+	deployment.Spec.Template.Annotations[rotatedAtAnnotation] = time.Now().Format(time.RFC3339)
+	_, err = deployments.Update(ctx, deployment, metav1.UpdateOptions{})
+	return err
+}
+
+func (r *Rotator) recordError(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status.LastError = err.Error()
+}
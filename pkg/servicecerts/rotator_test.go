@@ -0,0 +1,64 @@
+/*
+Copyright © 2025 MicroShift Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package servicecerts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/openshift/microshift/pkg/util/cryptomaterial"
+)
+
+func TestReadCertExpiryMatchesGeneratedCert(t *testing.T) {
+	crtPEM, _, err := cryptomaterial.NewSelfSignedCACertKey("test-signer", cryptomaterial.ValidityOneYear)
+	if err != nil {
+		t.Fatalf("error generating test signing cert: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "tls.crt")
+	if err := os.WriteFile(path, crtPEM, 0644); err != nil {
+		t.Fatalf("error writing test cert: %v", err)
+	}
+
+	expiry, err := readCertExpiry(path)
+	if err != nil {
+		t.Fatalf("readCertExpiry returned error: %v", err)
+	}
+
+	wantAfter := time.Now().Add(364 * 24 * time.Hour)
+	if expiry.Before(wantAfter) {
+		t.Fatalf("expected expiry after %s, got %s", wantAfter, expiry)
+	}
+}
+
+func TestReadCertExpiryMissingFile(t *testing.T) {
+	if _, err := readCertExpiry(filepath.Join(t.TempDir(), "does-not-exist.crt")); err == nil {
+		t.Fatalf("expected an error for a missing cert file")
+	}
+}
+
+func TestReadCertExpiryInvalidPEM(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tls.crt")
+	if err := os.WriteFile(path, []byte("not a pem file"), 0644); err != nil {
+		t.Fatalf("error writing test cert: %v", err)
+	}
+
+	if _, err := readCertExpiry(path); err == nil {
+		t.Fatalf("expected an error for a non-PEM cert file")
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Copyright © 2025 MicroShift Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package telemetry
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+)
+
+//go:embed data/metrics-allowlist.txt
+var defaultMetricsAllowlistRaw string
+
+// defaultMetricsAllowlist returns the built-in metric family allowlist used
+// when Telemetry.MetricsAllowlist is unset, modeled on the set Telemeter
+// ships for OpenShift clusters.
+func defaultMetricsAllowlist() []string {
+	var names []string
+	scanner := bufio.NewScanner(strings.NewReader(defaultMetricsAllowlistRaw))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, line)
+	}
+	return names
+}
@@ -31,6 +31,7 @@ import (
 	routev1 "github.com/openshift/client-go/route/clientset/versioned/typed/route/v1"
 	"github.com/openshift/microshift/pkg/config"
 	"github.com/openshift/microshift/pkg/util/cryptomaterial"
+	"github.com/prometheus/client_golang/prometheus"
 	io_prometheus_client "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/expfmt"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -109,18 +110,6 @@ func aggregateMetricValues(metrics []*io_prometheus_client.Metric) float64 {
 	return value
 }
 
-func filterMetricsByLabel(metrics []*io_prometheus_client.Metric, labelName string, labelValue string) []*io_prometheus_client.Metric {
-	filteredMetrics := make([]*io_prometheus_client.Metric, 0)
-	for _, metric := range metrics {
-		for _, label := range metric.Label {
-			if label.GetName() == labelName && label.GetValue() == labelValue {
-				filteredMetrics = append(filteredMetrics, metric)
-			}
-		}
-	}
-	return filteredMetrics
-}
-
 func filterMetricFamiliesByName(metricFamilies map[string]*io_prometheus_client.MetricFamily, names []string) map[string]*io_prometheus_client.MetricFamily {
 	filteredFamilies := make(map[string]*io_prometheus_client.MetricFamily)
 	for _, name := range names {
@@ -131,7 +120,7 @@ func filterMetricFamiliesByName(metricFamilies map[string]*io_prometheus_client.
 	return filteredFamilies
 }
 
-func fetchKubeletMetrics(cfg *config.Config) (map[string]*io_prometheus_client.MetricFamily, error) {
+func fetchKubeletMetrics(cfg *config.MicroshiftConfig) (map[string]*io_prometheus_client.MetricFamily, error) {
 	client, err := makeHTTPClient()
 	if err != nil {
 		return nil, fmt.Errorf("error creating HTTP client: %v", err)
@@ -155,7 +144,7 @@ func fetchKubeletMetrics(cfg *config.Config) (map[string]*io_prometheus_client.M
 	return metricFamilies, nil
 }
 
-func fetchNodeLabels(cfg *config.Config) (map[string]string, error) {
+func fetchNodeLabels(cfg *config.MicroshiftConfig) (map[string]string, error) {
 	kubeconfig := filepath.Join(cfg.KubeConfigRootAdminPath(), "kubeconfig")
 	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
 	if err != nil {
@@ -179,7 +168,7 @@ func fetchNodeLabels(cfg *config.Config) (map[string]string, error) {
 	return labels, nil
 }
 
-func fetchKubernetesResources(cfg *config.Config) (map[string]int, error) {
+func fetchKubernetesResources(cfg *config.MicroshiftConfig) (map[string]int, error) {
 	kubeconfig := filepath.Join(cfg.KubeConfigRootAdminPath(), "kubeconfig")
 	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
 	if err != nil {
@@ -264,3 +253,43 @@ func fetchOsVersionID() (string, error) {
 
 	return "", fmt.Errorf("VERSION_ID not found in /etc/os-release")
 }
+
+// Tick runs one telemetry collection cycle: it scrapes the local kubelet,
+// folds in MicroShift's own registered process metrics (e.g. the
+// service-ca signer's expiry gauges), and hands the result to pipeline,
+// which is responsible for allowlisting, relabeling, and shipping the
+// surviving series onward.
+func Tick(ctx context.Context, cfg *config.MicroshiftConfig, pipeline Pipeline) error {
+	metricFamilies, err := fetchKubeletMetrics(cfg)
+	if err != nil {
+		return fmt.Errorf("error gathering kubelet metrics: %w", err)
+	}
+
+	processFamilies, err := gatherProcessMetrics()
+	if err != nil {
+		return fmt.Errorf("error gathering process metrics: %w", err)
+	}
+	for name, family := range processFamilies {
+		metricFamilies[name] = family
+	}
+
+	externalLabels := map[string]string{
+		"node": cfg.Node.HostnameOverride,
+	}
+	return pipeline.Send(ctx, metricFamilies, externalLabels)
+}
+
+// gatherProcessMetrics collects everything registered against the default
+// Prometheus registry within this MicroShift process, e.g. the
+// servicecerts.Rotator's expiry and rotation gauges.
+func gatherProcessMetrics() (map[string]*io_prometheus_client.MetricFamily, error) {
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]*io_prometheus_client.MetricFamily, len(families))
+	for _, family := range families {
+		out[family.GetName()] = family
+	}
+	return out, nil
+}
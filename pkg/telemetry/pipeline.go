@@ -0,0 +1,247 @@
+/*
+Copyright © 2025 MicroShift Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/golang/snappy"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/pkg/relabel"
+	"github.com/prometheus/prometheus/prompb"
+	"k8s.io/klog/v2"
+
+	"github.com/openshift/microshift/pkg/config"
+)
+
+// Pipeline ships an allowlisted, relabeled subset of a metrics scrape to a
+// remote_write endpoint. Tick feeds it from the built-in kubelet scrape;
+// any other source of metric families, such as an in-cluster Prometheus
+// Agent, can call Send the same way.
+type Pipeline interface {
+	Send(ctx context.Context, metricFamilies map[string]*io_prometheus_client.MetricFamily, externalLabels map[string]string) error
+}
+
+// remoteWritePipeline is the default Pipeline: it filters metric families
+// down to an allowlist, applies relabel rules to the surviving series, and
+// POSTs the result as a snappy-compressed remote_write request.
+type remoteWritePipeline struct {
+	endpoint        string
+	allowlist       []string
+	relabelConfigs  []*relabel.Config
+	client          *http.Client
+	bearerTokenFile string
+}
+
+// NewPipeline builds the default remote_write Pipeline from cfg.Telemetry.
+// When MetricsAllowlist is empty it falls back to the embedded default
+// list. relabelConfigs is the set of Prometheus relabel rules applied to
+// every surviving series before it is shipped, e.g. to drop or hash the
+// "instance" label for privacy.
+func NewPipeline(cfg *config.MicroshiftConfig, relabelConfigs []*relabel.Config) (Pipeline, error) {
+	client, err := remoteWriteHTTPClient(cfg.Telemetry)
+	if err != nil {
+		return nil, fmt.Errorf("error creating remote_write HTTP client: %w", err)
+	}
+
+	allowlist := cfg.Telemetry.MetricsAllowlist
+	if len(allowlist) == 0 {
+		allowlist = defaultMetricsAllowlist()
+	}
+
+	return &remoteWritePipeline{
+		endpoint:        cfg.Telemetry.Endpoint,
+		allowlist:       allowlist,
+		relabelConfigs:  relabelConfigs,
+		client:          client,
+		bearerTokenFile: cfg.Telemetry.BearerTokenFile,
+	}, nil
+}
+
+func remoteWriteHTTPClient(cfg config.TelemetryConfig) (*http.Client, error) {
+	if cfg.TLSCertFile == "" && cfg.TLSKeyFile == "" && cfg.TLSCAFile == "" {
+		return &http.Client{}, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error loading client certificate and key: %w", err)
+	}
+	caCert, err := os.ReadFile(cfg.TLSCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading CA certificate: %w", err)
+	}
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("failed to add CA certificate to pool")
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:      caCertPool,
+				Certificates: []tls.Certificate{cert},
+			},
+		},
+	}, nil
+}
+
+// Send filters metricFamilies down to the pipeline's allowlist, relabels
+// the surviving series, and POSTs them to the configured remote_write
+// endpoint.
+func (p *remoteWritePipeline) Send(ctx context.Context, metricFamilies map[string]*io_prometheus_client.MetricFamily, externalLabels map[string]string) error {
+	allowed := filterMetricFamiliesByName(metricFamilies, p.allowlist)
+	logAllowedFamilies(allowed)
+
+	timeseries, err := relabelToTimeseries(allowed, p.relabelConfigs, externalLabels)
+	if err != nil {
+		return fmt.Errorf("error relabeling metrics: %w", err)
+	}
+	if len(timeseries) == 0 {
+		return nil
+	}
+
+	body, err := encodeWriteRequest(timeseries)
+	if err != nil {
+		return fmt.Errorf("error encoding remote_write request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error building remote_write request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	if p.bearerTokenFile != "" {
+		token, err := os.ReadFile(p.bearerTokenFile)
+		if err != nil {
+			return fmt.Errorf("error reading bearer token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+string(bytes.TrimSpace(token)))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending remote_write request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("remote_write endpoint returned status %s", resp.Status)
+	}
+	return nil
+}
+
+// relabelToTimeseries runs every metric in metricFamilies through
+// relabelConfigs, drops series relabel.Process rejects, and folds the
+// survivors into remote_write timeseries. relabel.Process alone handles
+// all keep/drop/replace decisions against the joined SourceLabels values;
+// because a "labeldrop" rule (e.g. stripping the per-node "instance"
+// label for privacy) can make previously distinct series collide on the
+// same label set afterwards, aggregateMetricValues - which used to sum a
+// whole hand-picked scrape - now does that same job one collision group
+// at a time.
+func relabelToTimeseries(metricFamilies map[string]*io_prometheus_client.MetricFamily, relabelConfigs []*relabel.Config, externalLabels map[string]string) ([]prompb.TimeSeries, error) {
+	type group struct {
+		labels  labels.Labels
+		metrics []*io_prometheus_client.Metric
+	}
+	groups := make(map[string]*group)
+	var order []string
+
+	for name, family := range metricFamilies {
+		for _, metric := range family.Metric {
+			lbls := labels.NewBuilder(labels.EmptyLabels())
+			lbls.Set(labels.MetricName, name)
+			for k, v := range externalLabels {
+				lbls.Set(k, v)
+			}
+			for _, label := range metric.Label {
+				lbls.Set(label.GetName(), label.GetValue())
+			}
+
+			relabeled := relabel.Process(lbls.Labels(), relabelConfigs...)
+			if relabeled.IsEmpty() {
+				// Dropped by an "action: drop"/"keep" rule.
+				continue
+			}
+
+			key := relabeled.String()
+			g, ok := groups[key]
+			if !ok {
+				g = &group{labels: relabeled}
+				groups[key] = g
+				order = append(order, key)
+			}
+			g.metrics = append(g.metrics, metric)
+		}
+	}
+
+	now := timeNowMillis()
+	out := make([]prompb.TimeSeries, 0, len(order))
+	for _, key := range order {
+		g := groups[key]
+		pbLabels := make([]prompb.Label, 0, len(g.labels))
+		for _, l := range g.labels {
+			pbLabels = append(pbLabels, prompb.Label{Name: l.Name, Value: l.Value})
+		}
+		out = append(out, prompb.TimeSeries{
+			Labels:  pbLabels,
+			Samples: []prompb.Sample{{Value: aggregateMetricValues(g.metrics), Timestamp: now}},
+		})
+	}
+	return out, nil
+}
+
+func encodeWriteRequest(timeseries []prompb.TimeSeries) ([]byte, error) {
+	req := &prompb.WriteRequest{Timeseries: timeseries}
+	data, err := req.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return snappy.Encode(nil, data), nil
+}
+
+func timeNowMillis() int64 {
+	return time.Now().UnixMilli()
+}
+
+// logAllowedFamilies re-serializes the allowlisted families via expfmt for
+// verbose debug logging, so operators can see exactly what a send is about
+// to ship without having to decode the protobuf batch on the wire.
+func logAllowedFamilies(metricFamilies map[string]*io_prometheus_client.MetricFamily) {
+	if !klog.V(4).Enabled() {
+		return
+	}
+	var buf bytes.Buffer
+	encoder := expfmt.NewEncoder(&buf, expfmt.NewFormat(expfmt.TypeTextPlain))
+	for _, family := range metricFamilies {
+		if err := encoder.Encode(family); err != nil {
+			klog.V(4).Infof("error re-serializing metric family %s for logging: %v", family.GetName(), err)
+			return
+		}
+	}
+	klog.V(4).Infof("sending allowlisted metrics:\n%s", buf.String())
+}
@@ -0,0 +1,112 @@
+/*
+Copyright © 2025 MicroShift Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package telemetry
+
+import (
+	"testing"
+
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	"github.com/prometheus/prometheus/pkg/relabel"
+)
+
+func TestDefaultMetricsAllowlistIncludesServiceCAGauges(t *testing.T) {
+	allowlist := defaultMetricsAllowlist()
+
+	want := []string{
+		"microshift_service_ca_signer_expiry_seconds",
+		"microshift_service_ca_signer_last_rotation_seconds",
+	}
+	for _, name := range want {
+		found := false
+		for _, got := range allowlist {
+			if got == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("default allowlist missing %q", name)
+		}
+	}
+}
+
+func TestDefaultMetricsAllowlistIgnoresCommentsAndBlankLines(t *testing.T) {
+	for _, name := range defaultMetricsAllowlist() {
+		if name == "" {
+			t.Fatalf("allowlist contains a blank entry")
+		}
+		if name[0] == '#' {
+			t.Fatalf("allowlist contains a comment line: %q", name)
+		}
+	}
+}
+
+func gaugeMetric(value float64, labels map[string]string) *io_prometheus_client.Metric {
+	m := &io_prometheus_client.Metric{Gauge: &io_prometheus_client.Gauge{Value: &value}}
+	for k, v := range labels {
+		name, val := k, v
+		m.Label = append(m.Label, &io_prometheus_client.LabelPair{Name: &name, Value: &val})
+	}
+	return m
+}
+
+func TestRelabelToTimeseriesDropsMetricsMatchingDropRule(t *testing.T) {
+	families := map[string]*io_prometheus_client.MetricFamily{
+		"node_cpu_seconds_total": {
+			Metric: []*io_prometheus_client.Metric{gaugeMetric(1, map[string]string{"mode": "idle"})},
+		},
+	}
+	relabelConfigs := []*relabel.Config{{
+		SourceLabels: []relabel.LabelName{"mode"},
+		Regex:        relabel.MustNewRegexp("idle"),
+		Action:       relabel.Drop,
+	}}
+
+	out, err := relabelToTimeseries(families, relabelConfigs, nil)
+	if err != nil {
+		t.Fatalf("relabelToTimeseries returned error: %v", err)
+	}
+	if len(out) != 0 {
+		t.Fatalf("expected the dropped series to be excluded, got %d timeseries", len(out))
+	}
+}
+
+func TestRelabelToTimeseriesAggregatesCollisionsAfterLabeldrop(t *testing.T) {
+	families := map[string]*io_prometheus_client.MetricFamily{
+		"kubelet_running_pods": {
+			Metric: []*io_prometheus_client.Metric{
+				gaugeMetric(2, map[string]string{"instance": "node-a"}),
+				gaugeMetric(3, map[string]string{"instance": "node-b"}),
+			},
+		},
+	}
+	relabelConfigs := []*relabel.Config{{
+		SourceLabels: []relabel.LabelName{"instance"},
+		Action:       relabel.LabelDrop,
+		Regex:        relabel.MustNewRegexp("instance"),
+	}}
+
+	out, err := relabelToTimeseries(families, relabelConfigs, nil)
+	if err != nil {
+		t.Fatalf("relabelToTimeseries returned error: %v", err)
+	}
+	if len(out) != 1 {
+		t.Fatalf("expected the two series to collapse into one after labeldrop, got %d", len(out))
+	}
+	if got, want := out[0].Samples[0].Value, 5.0; got != want {
+		t.Fatalf("expected aggregated value %v, got %v", want, got)
+	}
+}